@@ -0,0 +1,603 @@
+package lockotron
+
+import (
+	"container/heap"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("cached value not found")
+)
+
+type fallbackFunc[K comparable, V any] func(K) (V, error)
+
+type Cache[K comparable, V any] struct {
+	locker       *locker[K, V]
+	mutex        sync.RWMutex
+	items        map[K]*item[K, V]
+	queue        expirationQueue[K, V]
+	lru          *list.List
+	timerCh      chan time.Duration
+	stopChan     chan bool
+	ticker       *time.Ticker
+	config       *Config
+	metrics      cacheMetrics
+	hooksMu      sync.RWMutex
+	onInsertion  func(K, V)
+	onEviction   func(K, V, EvictionReason)
+	eventCh      chan cacheEvent[K, V]
+	eventBus     EventBus
+	subscription io.Closer
+	instanceID   string
+}
+
+func NewCache[K comparable, V any](config *Config) *Cache[K, V] {
+	eventBus := config.EventBus
+	if eventBus == nil {
+		eventBus = nopEventBus{}
+	}
+
+	c := &Cache[K, V]{
+		locker:     newLocker[K, V](),
+		items:      make(map[K]*item[K, V]),
+		config:     config,
+		stopChan:   make(chan bool),
+		timerCh:    make(chan time.Duration, 1),
+		eventCh:    make(chan cacheEvent[K, V], 256),
+		eventBus:   eventBus,
+		instanceID: newInstanceID(),
+	}
+
+	if config.Capacity > 0 {
+		c.lru = list.New()
+	}
+
+	if _, isNop := eventBus.(nopEventBus); !isNop {
+		if sub, err := eventBus.Subscribe(c.applyRemoteInvalidation); err == nil {
+			c.subscription = sub
+		}
+	}
+
+	go c.runExpirationScheduler()
+	go c.runEventDispatcher()
+
+	if config.CleanupInterval != NoCleaner {
+		c.ticker = time.NewTicker(config.CleanupInterval)
+
+		go func() {
+			for {
+				select {
+				case <-c.ticker.C:
+					c.DeleteExpired()
+				case <-c.stopChan:
+					c.ticker.Stop()
+
+					return
+				}
+			}
+		}()
+	}
+
+	return c
+}
+
+func (c *Cache[K, V]) Close() error {
+	close(c.stopChan)
+
+	if c.subscription != nil {
+		return c.subscription.Close()
+	}
+
+	return nil
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.set(key, c.config.DefaultTTL, value, true)
+}
+
+func (c *Cache[K, V]) SetEx(key K, ttl time.Duration, value V) {
+	c.set(key, ttl, value, true)
+}
+
+func (c *Cache[K, V]) Get(key K) (V, error) {
+	var item *item[K, V]
+	var ok bool
+
+	if c.lru == nil {
+		c.mutex.RLock()
+		item, ok = c.items[key]
+		c.mutex.RUnlock()
+	} else {
+		c.mutex.Lock()
+		item, ok = c.items[key]
+		if ok {
+			c.lru.MoveToFront(item.listElem)
+		}
+		c.mutex.Unlock()
+	}
+
+	if ok {
+		c.metrics.hits.Add(1)
+
+		return item.value, nil
+	}
+
+	c.metrics.misses.Add(1)
+
+	var zero V
+
+	return zero, ErrNotFound
+}
+
+func (c *Cache[K, V]) GetList(keys []K) []V {
+	values, ok := c.getIndexed(keys)
+
+	result := make([]V, 0, len(keys))
+
+	for i, present := range ok {
+		if present {
+			result = append(result, values[i])
+		}
+	}
+
+	return result
+}
+
+// getIndexed looks up keys under a single lock acquisition, returning a
+// value slice and a parallel presence slice both aligned with keys. This
+// lets callers that need to know which key a hit belongs to (such as
+// ShardedCache reassembling results across shards) avoid re-deriving it,
+// while GetList itself just filters down to the hits.
+func (c *Cache[K, V]) getIndexed(keys []K) ([]V, []bool) {
+	values := make([]V, len(keys))
+	ok := make([]bool, len(keys))
+
+	lookup := func(key K) (*item[K, V], bool) {
+		it, present := c.items[key]
+		if present && c.lru != nil {
+			c.lru.MoveToFront(it.listElem)
+		}
+
+		return it, present
+	}
+
+	if c.lru == nil {
+		c.mutex.RLock()
+		defer c.mutex.RUnlock()
+	} else {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+
+	for i, key := range keys {
+		it, present := lookup(key)
+		if !present {
+			c.metrics.misses.Add(1)
+
+			continue
+		}
+
+		c.metrics.hits.Add(1)
+		values[i] = it.value
+		ok[i] = true
+	}
+
+	return values, ok
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mutex.Lock()
+	value, ok := c.removeLocked(key)
+	c.mutex.Unlock()
+
+	if ok {
+		c.emitEviction(key, value, ReasonDeleted)
+	}
+
+	c.rearm()
+	c.publishInvalidation(key)
+}
+
+func (c *Cache[K, V]) DeleteList(keys []K) {
+	type removed struct {
+		key   K
+		value V
+	}
+
+	c.mutex.Lock()
+	evicted := make([]removed, 0, len(keys))
+
+	for _, key := range keys {
+		if value, ok := c.removeLocked(key); ok {
+			evicted = append(evicted, removed{key: key, value: value})
+		}
+	}
+	c.mutex.Unlock()
+
+	for _, r := range evicted {
+		c.emitEviction(r.key, r.value, ReasonDeleted)
+	}
+
+	c.rearm()
+
+	for _, key := range keys {
+		c.publishInvalidation(key)
+	}
+}
+
+func (c *Cache[K, V]) Fetch(key K, fallback fallbackFunc[K, V]) (V, error) {
+	return c.fetch(key, c.config.DefaultTTL, fallback)
+}
+
+func (c *Cache[K, V]) FetchEx(key K, ttl time.Duration, fallback fallbackFunc[K, V]) (V, error) {
+	return c.fetch(key, ttl, fallback)
+}
+
+func (c *Cache[K, V]) DeleteAll() {
+	type removed struct {
+		key   K
+		value V
+	}
+
+	c.mutex.Lock()
+	evicted := make([]removed, 0, len(c.items))
+
+	for key, it := range c.items {
+		evicted = append(evicted, removed{key: key, value: it.value})
+	}
+
+	c.items = make(map[K]*item[K, V])
+	c.queue = nil
+	if c.lru != nil {
+		c.lru = list.New()
+	}
+	c.mutex.Unlock()
+
+	for _, r := range evicted {
+		c.emitEviction(r.key, r.value, ReasonDeleted)
+	}
+
+	c.rearm()
+
+	if _, isNop := c.eventBus.(nopEventBus); !isNop {
+		_ = c.eventBus.Publish(encodeInvalidation(c.instanceID, allKeysInvalidation))
+	}
+}
+
+// DeleteExpired pops every item whose ttl has elapsed off the expiration
+// queue. It is called both by the event-driven scheduler and, as a safety
+// net, by the periodic cleanup ticker when one is configured.
+func (c *Cache[K, V]) DeleteExpired() {
+	type expired struct {
+		key   K
+		value V
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mutex.Lock()
+	var due []expired
+
+	for {
+		next := c.queue.peek()
+		if next == nil || next.ttl > now {
+			break
+		}
+
+		popped, _ := heap.Pop(&c.queue).(*item[K, V])
+		if c.lru != nil {
+			c.lru.Remove(popped.listElem)
+		}
+		delete(c.items, popped.key)
+
+		due = append(due, expired{key: popped.key, value: popped.value})
+	}
+	c.mutex.Unlock()
+
+	for _, e := range due {
+		c.emitEviction(e.key, e.value, ReasonExpired)
+	}
+}
+
+func (c *Cache[K, V]) fetch(key K, ttl time.Duration, fallback fallbackFunc[K, V]) (V, error) {
+	value, err := c.Get(key)
+	if err == nil {
+		return value, nil
+	}
+
+	sfCall, created := c.locker.loadOrCreate(key)
+	if !created {
+		sfCall.wg.Wait()
+		c.locker.release(key, sfCall)
+
+		return sfCall.value, sfCall.err
+	}
+
+	defer c.locker.release(key, sfCall)
+
+	value, err = c.Get(key)
+	if err != nil {
+		c.metrics.fallbackCalls.Add(1)
+
+		value, err = fallback(key)
+		if err == nil {
+			// Persist the fallback's result, but don't double-count it
+			// as an insertion: Metrics.Insertions tracks explicit
+			// Set/SetEx calls, and this one is driven by a cache miss.
+			c.set(key, ttl, value, false)
+		}
+	}
+
+	sfCall.value = value
+	sfCall.err = err
+	sfCall.wg.Done()
+
+	if err != nil {
+		var zero V
+
+		return zero, err
+	}
+
+	return value, nil
+}
+
+// set stores value under key. countInsertion controls whether the write
+// is counted in Metrics.Insertions: true for an explicit Set/SetEx, false
+// when fetch is merely persisting a fallback's result for a cache miss.
+func (c *Cache[K, V]) set(key K, ttl time.Duration, value V, countInsertion bool) {
+	var (
+		replacedValue V
+		replaced      bool
+		evictedKey    K
+		evictedVal    V
+		evicted       bool
+	)
+
+	c.mutex.Lock()
+
+	if existing, ok := c.items[key]; ok {
+		replacedValue = existing.value
+		replaced = true
+
+		wasExpirable := existing.isExpirable()
+		existing.value = value
+
+		if ttl == NoTTL {
+			existing.ttl = int64(NoTTL)
+		} else {
+			existing.ttl = time.Now().Add(ttl).UnixNano()
+		}
+
+		switch {
+		case wasExpirable && existing.isExpirable():
+			heap.Fix(&c.queue, existing.heapIndex)
+		case wasExpirable && !existing.isExpirable():
+			heap.Remove(&c.queue, existing.heapIndex)
+		case !wasExpirable && existing.isExpirable():
+			heap.Push(&c.queue, existing)
+		}
+
+		if c.lru != nil {
+			c.lru.MoveToFront(existing.listElem)
+		}
+	} else {
+		it := newItem(key, value, ttl)
+		c.items[key] = it
+
+		if it.isExpirable() {
+			heap.Push(&c.queue, it)
+		}
+
+		if c.lru != nil {
+			it.listElem = c.lru.PushFront(key)
+
+			if uint64(len(c.items)) > c.config.Capacity {
+				evictedKey, evictedVal, evicted = c.evictOldestLocked()
+			}
+		}
+	}
+
+	if countInsertion {
+		c.metrics.insertions.Add(1)
+	}
+
+	c.mutex.Unlock()
+
+	if replaced {
+		c.emitEviction(key, replacedValue, ReasonReplaced)
+	}
+
+	if evicted {
+		c.emitEviction(evictedKey, evictedVal, ReasonCapacity)
+	}
+
+	c.emitInsertion(key, value)
+
+	c.rearm()
+	c.publishInvalidation(key)
+}
+
+// publishInvalidation tells the configured EventBus that key was written
+// or removed locally, so other processes sharing it can drop their own
+// copy. It is a no-op when no bus is configured.
+func (c *Cache[K, V]) publishInvalidation(key K) {
+	if _, isNop := c.eventBus.(nopEventBus); isNop {
+		return
+	}
+
+	_ = c.eventBus.Publish(encodeInvalidation(c.instanceID, fmt.Sprintf("%v", key)))
+}
+
+// applyRemoteInvalidation is the callback registered with the EventBus. It
+// ignores echoes of this Cache's own messages and, when the key can be
+// converted back to a K, deletes it locally without re-publishing.
+func (c *Cache[K, V]) applyRemoteInvalidation(message string) {
+	instanceID, encodedKey, ok := decodeInvalidation(message)
+	if !ok || instanceID == c.instanceID {
+		return
+	}
+
+	if encodedKey == allKeysInvalidation {
+		type removed struct {
+			key   K
+			value V
+		}
+
+		c.mutex.Lock()
+		evicted := make([]removed, 0, len(c.items))
+
+		for key, it := range c.items {
+			evicted = append(evicted, removed{key: key, value: it.value})
+		}
+
+		c.items = make(map[K]*item[K, V])
+		c.queue = nil
+		if c.lru != nil {
+			c.lru = list.New()
+		}
+		c.mutex.Unlock()
+
+		for _, r := range evicted {
+			c.emitEviction(r.key, r.value, ReasonDeleted)
+		}
+
+		c.rearm()
+
+		return
+	}
+
+	key, ok := decodeKey[K](encodedKey)
+	if !ok {
+		return
+	}
+
+	c.mutex.Lock()
+	value, ok := c.removeLocked(key)
+	c.mutex.Unlock()
+
+	if ok {
+		c.emitEviction(key, value, ReasonDeleted)
+	}
+
+	c.rearm()
+}
+
+// removeLocked deletes key from items and, if it carries a ttl or a place
+// in the LRU list, from those too, reporting the removed value so the
+// caller can emit the right eviction event once the lock has been
+// released. Callers must hold c.mutex.
+func (c *Cache[K, V]) removeLocked(key K) (value V, ok bool) {
+	existing, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+
+	if existing.isExpirable() {
+		heap.Remove(&c.queue, existing.heapIndex)
+	}
+
+	if c.lru != nil {
+		c.lru.Remove(existing.listElem)
+	}
+
+	delete(c.items, key)
+
+	return existing.value, true
+}
+
+// evictOldestLocked removes the least recently used item. Callers must
+// hold c.mutex and know c.lru is non-nil.
+func (c *Cache[K, V]) evictOldestLocked() (key K, value V, ok bool) {
+	back := c.lru.Back()
+	if back == nil {
+		return key, value, false
+	}
+
+	key, _ = back.Value.(K)
+	value, ok = c.removeLocked(key)
+
+	return key, value, ok
+}
+
+// queueLen reports the number of expirable items currently tracked by the
+// expiration queue, synchronized against the writers that mutate it
+// (Set/SetEx via heap.Push, the scheduler's DeleteExpired via heap.Pop).
+func (c *Cache[K, V]) queueLen() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.queue.Len()
+}
+
+// rearm tells the expiration scheduler to recompute its timer against the
+// current head of the queue.
+func (c *Cache[K, V]) rearm() {
+	c.mutex.RLock()
+	next := c.queue.peek()
+	c.mutex.RUnlock()
+
+	d := time.Duration(-1)
+	if next != nil {
+		d = time.Duration(next.ttl - time.Now().UnixNano())
+		if d < 0 {
+			// The head is already overdue; clamp instead of passing the
+			// negative duration through, or arm would mistake it for the
+			// "queue empty" sentinel and park the timer with an expired
+			// item still sitting in the queue.
+			d = 0
+		}
+	}
+
+	for {
+		select {
+		case c.timerCh <- d:
+			return
+		default:
+			select {
+			case <-c.timerCh:
+			default:
+			}
+		}
+	}
+}
+
+// runExpirationScheduler keeps a single timer armed for exactly the next
+// expiration in the queue, avoiding both a fixed cleanup latency and a
+// full scan of items on every tick. It parks indefinitely whenever the
+// queue is empty and wakes up again once rearm reports a new deadline.
+func (c *Cache[K, V]) runExpirationScheduler() {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+
+	arm := func(d time.Duration) {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		if d >= 0 {
+			timer.Reset(d)
+		}
+	}
+
+	for {
+		select {
+		case d := <-c.timerCh:
+			arm(d)
+		case <-timer.C:
+			c.DeleteExpired()
+			c.rearm()
+		case <-c.stopChan:
+			timer.Stop()
+
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package lockotron
+
+import (
+	"time"
+)
+
+const (
+	NoTTL     time.Duration = -1
+	NoCleaner time.Duration = -1
+)
+
+type Config struct {
+	DefaultTTL      time.Duration
+	CleanupInterval time.Duration
+
+	// Capacity bounds the number of items the cache holds. Once exceeded,
+	// the least recently used item is evicted. Zero means unbounded.
+	Capacity uint64
+
+	// EventBus, when set, lets this Cache publish invalidation messages to
+	// other processes on Set/Delete/DeleteList/DeleteAll and apply
+	// messages published by them locally. Defaults to a no-op bus.
+	EventBus EventBus
+}
+
+// Option configures a Config returned by NewConfig.
+type Option func(*Config)
+
+// WithCapacity bounds the cache to the given number of items, evicting the
+// least recently used one once the bound is exceeded.
+func WithCapacity(capacity uint64) Option {
+	return func(c *Config) {
+		c.Capacity = capacity
+	}
+}
+
+// WithEventBus configures the bus used to publish and receive cache
+// invalidation messages across processes sharing an upstream data source.
+func WithEventBus(bus EventBus) Option {
+	return func(c *Config) {
+		c.EventBus = bus
+	}
+}
+
+func NewConfig(opts ...Option) *Config {
+	config := &Config{
+		DefaultTTL:      15 * time.Minute,
+		CleanupInterval: 10 * time.Minute,
+		EventBus:        nopEventBus{},
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
@@ -0,0 +1,88 @@
+package lockotron
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// EventBus lets Cache instances running in different processes, but
+// sharing the same upstream data source, invalidate each other's entries
+// without polling. Publish is called with a message whenever this process
+// writes or removes a key. Subscribe registers the callback invoked when
+// another process's message arrives and returns a handle scoped to that
+// one call; a bus is expected to be shared across many Cache instances
+// (ShardedCache hands the same bus to every shard), so each subscriber
+// must be able to unsubscribe without tearing down anyone else's. Both
+// sides are free to be a no-op, which is exactly what the default bus
+// does.
+type EventBus interface {
+	Publish(key string) error
+	Subscribe(fn func(key string)) (io.Closer, error)
+}
+
+// nopEventBus is the default EventBus: Publish does nothing and Subscribe
+// never delivers anything, so a Cache with no bus configured behaves
+// exactly as it did before EventBus existed.
+type nopEventBus struct{}
+
+func (nopEventBus) Publish(string) error                      { return nil }
+func (nopEventBus) Subscribe(func(string)) (io.Closer, error) { return nil, nil }
+
+// newInstanceID returns a fresh ID for a Cache to tag every invalidation
+// message it publishes, so it can tell its own echo, relayed back by the
+// bus, apart from a message that genuinely originated elsewhere. Each
+// Cache gets its own ID: a process-wide ID would make every Cache (and
+// every shard of a ShardedCache, which constructs one per shard) treat a
+// sibling's messages as its own echo and silently drop them.
+func newInstanceID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+// invalidationSeparator joins an instance ID to the key (or the
+// allKeysInvalidation sentinel) it applies to. A NUL byte can't occur in
+// either part of a well-formed message.
+const invalidationSeparator = "\x00"
+
+// allKeysInvalidation is published by DeleteAll in place of a single key,
+// telling subscribers to clear their whole cache rather than one entry.
+const allKeysInvalidation = "*"
+
+func encodeInvalidation(instanceID, key string) string {
+	return instanceID + invalidationSeparator + key
+}
+
+func decodeInvalidation(message string) (instanceID, key string, ok bool) {
+	instanceID, key, ok = strings.Cut(message, invalidationSeparator)
+
+	return instanceID, key, ok
+}
+
+// decodeKey converts the string payload of an invalidation message back
+// into a K. It succeeds both when K is string itself and when K is a
+// named type whose underlying type is string (e.g. type UserID string) -
+// a plain type assertion only covers the former, so named string keys go
+// through reflect.Value.Convert instead. For any other key type, messages
+// are still published (useful to a Redis-backed audit consumer, say) but
+// never applied locally.
+func decodeKey[K comparable](key string) (K, bool) {
+	if k, ok := any(key).(K); ok {
+		return k, true
+	}
+
+	var zero K
+
+	keyType := reflect.TypeOf(zero)
+	if keyType == nil || keyType.Kind() != reflect.String {
+		return zero, false
+	}
+
+	k, _ := reflect.ValueOf(key).Convert(keyType).Interface().(K)
+
+	return k, true
+}
@@ -0,0 +1,172 @@
+package lockotron
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventBus is an in-process EventBus that fans every published message
+// out to all subscribers, standing in for a real pub/sub transport. Like
+// the real Redis bus, it is meant to be shared across several Cache
+// instances at once, so each Subscribe call gets back a handle that
+// unsubscribes only that one caller.
+type fakeEventBus struct {
+	mutex       sync.Mutex
+	subscribers []*fakeSubscription
+}
+
+type fakeSubscription struct {
+	bus *fakeEventBus
+	fn  func(string)
+}
+
+func (b *fakeEventBus) Publish(key string) error {
+	b.mutex.Lock()
+	fns := make([]func(string), len(b.subscribers))
+	for i, sub := range b.subscribers {
+		fns[i] = sub.fn
+	}
+	b.mutex.Unlock()
+
+	for _, fn := range fns {
+		fn(key)
+	}
+
+	return nil
+}
+
+func (b *fakeEventBus) Subscribe(fn func(key string)) (io.Closer, error) {
+	sub := &fakeSubscription{bus: b, fn: fn}
+
+	b.mutex.Lock()
+	b.subscribers = append(b.subscribers, sub)
+	b.mutex.Unlock()
+
+	return sub, nil
+}
+
+func (s *fakeSubscription) Close() error {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+
+	for i, sub := range s.bus.subscribers {
+		if sub == s {
+			s.bus.subscribers = append(s.bus.subscribers[:i], s.bus.subscribers[i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func TestCache_EventBus(t *testing.T) {
+	t.Run("It invalidates a key set on one cache from another sharing the bus", func(t *testing.T) {
+		bus := &fakeEventBus{}
+
+		cache1 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache1.Close()
+
+		cache2 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache2.Close()
+
+		cache1.Set("key", "value")
+		cache2.Set("key", "value")
+
+		cache1.Delete("key")
+
+		require.Eventually(t, func() bool {
+			_, err := cache2.Get("key")
+
+			return err == ErrNotFound
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("It ignores its own published messages", func(t *testing.T) {
+		bus := &fakeEventBus{}
+
+		cache := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache.Close()
+
+		cache.Set("key", "value")
+
+		value, err := cache.Get("key")
+
+		require.Nil(t, err)
+		require.Equal(t, "value", value)
+	})
+
+	t.Run("It clears every key on another cache when DeleteAll is published", func(t *testing.T) {
+		bus := &fakeEventBus{}
+
+		cache1 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache1.Close()
+
+		cache2 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache2.Close()
+
+		cache2.Set("key1", "value1")
+		cache2.Set("key2", "value2")
+
+		cache1.DeleteAll()
+
+		require.Eventually(t, func() bool {
+			_, err1 := cache2.Get("key1")
+			_, err2 := cache2.Get("key2")
+
+			return err1 == ErrNotFound && err2 == ErrNotFound
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("It invalidates a named string key type, not just plain string", func(t *testing.T) {
+		type UserID string
+
+		bus := &fakeEventBus{}
+
+		cache1 := NewCache[UserID, string](NewConfig(WithEventBus(bus)))
+		defer cache1.Close()
+
+		cache2 := NewCache[UserID, string](NewConfig(WithEventBus(bus)))
+		defer cache2.Close()
+
+		cache2.Set(UserID("user-1"), "value")
+
+		cache1.Delete(UserID("user-1"))
+
+		require.Eventually(t, func() bool {
+			_, err := cache2.Get(UserID("user-1"))
+
+			return err == ErrNotFound
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("It closes only its own subscription when closed, not one sharing the bus", func(t *testing.T) {
+		bus := &fakeEventBus{}
+
+		cache1 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		cache2 := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache2.Close()
+
+		require.Len(t, bus.subscribers, 2)
+
+		require.Nil(t, cache1.Close())
+
+		require.Len(t, bus.subscribers, 1)
+
+		cache1Again := NewCache[string, string](NewConfig(WithEventBus(bus)))
+		defer cache1Again.Close()
+
+		cache2.Set("key", "value")
+		cache1Again.Delete("key")
+
+		require.Eventually(t, func() bool {
+			_, err := cache2.Get("key")
+
+			return err == ErrNotFound
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+}
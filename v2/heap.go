@@ -0,0 +1,40 @@
+package lockotron
+
+// expirationQueue is a container/heap of the expirable items in a Cache,
+// ordered by ttl ascending so the soonest expiration is always at index 0.
+type expirationQueue[K comparable, V any] []*item[K, V]
+
+func (q expirationQueue[K, V]) Len() int { return len(q) }
+
+func (q expirationQueue[K, V]) Less(i, j int) bool { return q[i].ttl < q[j].ttl }
+
+func (q expirationQueue[K, V]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].heapIndex = i
+	q[j].heapIndex = j
+}
+
+func (q *expirationQueue[K, V]) Push(x any) {
+	it, _ := x.(*item[K, V])
+	it.heapIndex = len(*q)
+	*q = append(*q, it)
+}
+
+func (q *expirationQueue[K, V]) Pop() any {
+	old := *q
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	it.heapIndex = -1
+	*q = old[:n-1]
+
+	return it
+}
+
+func (q expirationQueue[K, V]) peek() *item[K, V] {
+	if len(q) == 0 {
+		return nil
+	}
+
+	return q[0]
+}
@@ -0,0 +1,72 @@
+package lockotron
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpirationQueue(t *testing.T) {
+	t.Run("It pops items in ttl order", func(t *testing.T) {
+		var queue expirationQueue[string, string]
+
+		heap.Push(&queue, &item[string, string]{key: "c", ttl: 3})
+		heap.Push(&queue, &item[string, string]{key: "a", ttl: 1})
+		heap.Push(&queue, &item[string, string]{key: "b", ttl: 2})
+
+		keys := make([]string, 0, 3)
+		for queue.Len() > 0 {
+			popped, _ := heap.Pop(&queue).(*item[string, string])
+			keys = append(keys, popped.key)
+		}
+
+		require.Equal(t, []string{"a", "b", "c"}, keys)
+	})
+}
+
+func TestCache_expirationScheduler(t *testing.T) {
+	config := NewConfig()
+	config.CleanupInterval = NoCleaner
+
+	t.Run("It expires items close to their ttl without waiting for a cleanup tick", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.SetEx("key", 10*time.Millisecond, "value")
+
+		<-time.After(25 * time.Millisecond)
+
+		value, err := cache.Get("key")
+
+		require.Equal(t, "", value)
+		require.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("It does not enqueue items with no ttl", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.SetEx("key", NoTTL, "value")
+
+		require.Equal(t, 0, cache.queueLen())
+	})
+
+	t.Run("It reuses the heap slot and reschedules on SetEx for an existing key", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.SetEx("key", time.Hour, "value")
+		cache.SetEx("key", 10*time.Millisecond, "value2")
+
+		require.Equal(t, 1, cache.queueLen())
+
+		<-time.After(25 * time.Millisecond)
+
+		value, err := cache.Get("key")
+
+		require.Equal(t, "", value)
+		require.Equal(t, ErrNotFound, err)
+	})
+}
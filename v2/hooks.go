@@ -0,0 +1,69 @@
+package lockotron
+
+// cacheEvent is dispatched to runEventDispatcher so that OnInsertion and
+// OnEviction callbacks never run on a goroutine holding c.mutex.
+type cacheEvent[K comparable, V any] struct {
+	insertion bool
+	key       K
+	value     V
+	reason    EvictionReason
+}
+
+// OnInsertion registers fn to be called, from a dedicated goroutine,
+// whenever a value is stored in the cache. Only one callback can be
+// registered at a time; calling OnInsertion again replaces it.
+func (c *Cache[K, V]) OnInsertion(fn func(key K, value V)) {
+	c.hooksMu.Lock()
+	c.onInsertion = fn
+	c.hooksMu.Unlock()
+}
+
+// OnEviction registers fn to be called, from a dedicated goroutine,
+// whenever a value leaves the cache. Only one callback can be registered
+// at a time; calling OnEviction again replaces it.
+func (c *Cache[K, V]) OnEviction(fn func(key K, value V, reason EvictionReason)) {
+	c.hooksMu.Lock()
+	c.onEviction = fn
+	c.hooksMu.Unlock()
+}
+
+func (c *Cache[K, V]) emitInsertion(key K, value V) {
+	select {
+	case c.eventCh <- cacheEvent[K, V]{insertion: true, key: key, value: value}:
+	case <-c.stopChan:
+	}
+}
+
+func (c *Cache[K, V]) emitEviction(key K, value V, reason EvictionReason) {
+	c.metrics.recordEviction(reason)
+
+	select {
+	case c.eventCh <- cacheEvent[K, V]{key: key, value: value, reason: reason}:
+	case <-c.stopChan:
+	}
+}
+
+// runEventDispatcher delivers queued events to the registered hooks on its
+// own goroutine so that a hook calling back into the cache cannot deadlock
+// the caller that triggered the event.
+func (c *Cache[K, V]) runEventDispatcher() {
+	for {
+		select {
+		case ev := <-c.eventCh:
+			c.hooksMu.RLock()
+			onInsertion := c.onInsertion
+			onEviction := c.onEviction
+			c.hooksMu.RUnlock()
+
+			if ev.insertion {
+				if onInsertion != nil {
+					onInsertion(ev.key, ev.value)
+				}
+			} else if onEviction != nil {
+				onEviction(ev.key, ev.value, ev.reason)
+			}
+		case <-c.stopChan:
+			return
+		}
+	}
+}
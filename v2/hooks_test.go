@@ -0,0 +1,140 @@
+package lockotron
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Hooks(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It calls OnInsertion for new and replaced values", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		var mu sync.Mutex
+		var insertions []string
+
+		cache.OnInsertion(func(key string, value string) {
+			mu.Lock()
+			insertions = append(insertions, value)
+			mu.Unlock()
+		})
+
+		cache.Set("key", "value1")
+		cache.Set("key", "value2")
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(insertions) == 2
+		}, 100*time.Millisecond, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []string{"value1", "value2"}, insertions)
+	})
+
+	t.Run("It calls OnEviction with the replaced reason when a key is overwritten", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+
+		cache.OnEviction(func(key string, value string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		cache.Set("key", "value1")
+		cache.Set("key", "value2")
+		cache.Delete("key")
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(reasons) == 2
+		}, 100*time.Millisecond, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []EvictionReason{ReasonReplaced, ReasonDeleted}, reasons)
+	})
+
+	t.Run("It calls OnEviction for every key cleared by DeleteAll", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		var mu sync.Mutex
+		var reasons []EvictionReason
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+
+		cache.OnEviction(func(key string, value string, reason EvictionReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		})
+
+		cache.DeleteAll()
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(reasons) == 2
+		}, 100*time.Millisecond, time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []EvictionReason{ReasonDeleted, ReasonDeleted}, reasons)
+	})
+}
+
+func TestCache_Metrics(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It counts insertions, hits, misses, evictions and fallback calls", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.Set("key", "value")
+		cache.Get("key")
+		cache.Get("missing")
+		cache.Delete("key")
+		cache.Fetch("other", func(string) (string, error) {
+			return "value", nil
+		})
+
+		metrics := cache.Metrics()
+
+		require.Equal(t, uint64(1), metrics.Insertions)
+		require.Equal(t, uint64(1), metrics.Hits)
+		require.Equal(t, uint64(3), metrics.Misses)
+		require.Equal(t, uint64(1), metrics.Evictions)
+		require.Equal(t, uint64(1), metrics.EvictionsByReason[ReasonDeleted])
+		require.Equal(t, uint64(1), metrics.FallbackCalls)
+	})
+
+	t.Run("It counts every key cleared by DeleteAll as an eviction", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.DeleteAll()
+
+		metrics := cache.Metrics()
+
+		require.Equal(t, uint64(2), metrics.Evictions)
+		require.Equal(t, uint64(2), metrics.EvictionsByReason[ReasonDeleted])
+	})
+}
@@ -0,0 +1,34 @@
+package lockotron
+
+import (
+	"container/list"
+	"time"
+)
+
+type item[K comparable, V any] struct {
+	key       K
+	value     V
+	ttl       int64
+	heapIndex int
+	listElem  *list.Element
+}
+
+func newItem[K comparable, V any](key K, value V, ttl time.Duration) *item[K, V] {
+	it := &item[K, V]{key: key, value: value, heapIndex: -1}
+
+	if ttl == NoTTL {
+		it.ttl = int64(NoTTL)
+	} else {
+		it.ttl = time.Now().Add(ttl).UnixNano()
+	}
+
+	return it
+}
+
+func (i *item[K, V]) isExpirable() bool {
+	if i.ttl == int64(NoTTL) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,60 @@
+package lockotron
+
+import (
+	"sync"
+)
+
+// call represents a single in-flight (or just-finished) Fetch for one key,
+// shared by every goroutine racing to compute it. This is what makes
+// Cache.fetch a proper singleflight: the old design handed out a fresh
+// *deadlock.Mutex per obtain/release pair, so a goroutine that arrived
+// between another's obtain and release could get a brand-new mutex and
+// run the fallback again despite the intended single-flighting.
+type call[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	err   error
+	refs  int
+}
+
+type locker[K comparable, V any] struct {
+	mutex sync.Mutex
+	calls map[K]*call[V]
+}
+
+func newLocker[K comparable, V any]() *locker[K, V] {
+	return &locker[K, V]{calls: make(map[K]*call[V])}
+}
+
+// loadOrCreate returns the call in flight for key, creating one if none
+// exists. created is true only for the caller that must run the fallback
+// and eventually mark the call done; every other caller should wg.Wait()
+// on the returned call and then release it.
+func (l *locker[K, V]) loadOrCreate(key K) (c *call[V], created bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if existing, ok := l.calls[key]; ok {
+		existing.refs++
+
+		return existing, false
+	}
+
+	c = &call[V]{refs: 1}
+	c.wg.Add(1)
+	l.calls[key] = c
+
+	return c, true
+}
+
+// release drops one reference to key's call, deleting it from the map
+// once every caller that joined it has released theirs.
+func (l *locker[K, V]) release(key K, c *call[V]) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	c.refs--
+	if c.refs == 0 {
+		delete(l.calls, key)
+	}
+}
@@ -0,0 +1,112 @@
+package lockotron
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Fetch_Singleflight(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It runs a slow fallback exactly once for >1000 concurrent callers and shares the value", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		const concurrency = 1500
+
+		var calls uint32
+
+		values := make([]string, concurrency)
+		errs := make([]error, concurrency)
+
+		var started, wg sync.WaitGroup
+		started.Add(concurrency)
+		wg.Add(concurrency)
+		ready := make(chan struct{})
+
+		for i := 0; i < concurrency; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				started.Done()
+				<-ready
+
+				values[i], errs[i] = cache.Fetch("key", func(string) (string, error) {
+					atomic.AddUint32(&calls, 1)
+					time.Sleep(50 * time.Millisecond)
+
+					return "value", nil
+				})
+			}(i)
+		}
+
+		// Hold every goroutine back until all of them are alive and
+		// blocked on ready, so they all race into Fetch at once instead
+		// of trickling in over the time it takes to schedule 1500
+		// goroutines - the latter can let a straggler arrive after the
+		// in-flight call already settled. Here the straggler would find
+		// the cached value; in the error case below, where nothing is
+		// cached, it would legitimately start a second call.
+		started.Wait()
+		close(ready)
+		wg.Wait()
+
+		require.Equal(t, uint32(1), atomic.LoadUint32(&calls))
+
+		for i := 0; i < concurrency; i++ {
+			require.Nil(t, errs[i])
+			require.Equal(t, "value", values[i])
+		}
+	})
+
+	t.Run("It propagates the fallback error to every follower", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		const concurrency = 1500
+
+		expectedErr := errors.New("terrible error")
+
+		var calls uint32
+
+		values := make([]string, concurrency)
+		errs := make([]error, concurrency)
+
+		var started, wg sync.WaitGroup
+		started.Add(concurrency)
+		wg.Add(concurrency)
+		ready := make(chan struct{})
+
+		for i := 0; i < concurrency; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				started.Done()
+				<-ready
+
+				values[i], errs[i] = cache.Fetch("key-error", func(string) (string, error) {
+					atomic.AddUint32(&calls, 1)
+					time.Sleep(50 * time.Millisecond)
+
+					return "", expectedErr
+				})
+			}(i)
+		}
+
+		started.Wait()
+		close(ready)
+		wg.Wait()
+
+		require.Equal(t, uint32(1), atomic.LoadUint32(&calls))
+
+		for i := 0; i < concurrency; i++ {
+			require.Equal(t, expectedErr, errs[i])
+			require.Equal(t, "", values[i])
+		}
+	})
+}
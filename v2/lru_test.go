@@ -0,0 +1,44 @@
+package lockotron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Capacity(t *testing.T) {
+	config := NewConfig(WithCapacity(2))
+
+	t.Run("It evicts the least recently used item once capacity is exceeded", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.Set("key3", "value3")
+
+		_, err := cache.Get("key1")
+		value3, err3 := cache.Get("key3")
+
+		require.Equal(t, ErrNotFound, err)
+		require.Nil(t, err3)
+		require.Equal(t, "value3", value3)
+	})
+
+	t.Run("It keeps a recently read item alive over one that hasn't been touched", func(t *testing.T) {
+		cache := NewCache[string, string](config)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.Get("key1")
+		cache.Set("key3", "value3")
+
+		value1, err1 := cache.Get("key1")
+		_, err2 := cache.Get("key2")
+
+		require.Nil(t, err1)
+		require.Equal(t, "value1", value1)
+		require.Equal(t, ErrNotFound, err2)
+	})
+}
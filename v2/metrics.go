@@ -0,0 +1,64 @@
+package lockotron
+
+import "sync/atomic"
+
+// EvictionReason identifies why an item left the cache.
+type EvictionReason int
+
+const (
+	ReasonExpired EvictionReason = iota
+	ReasonDeleted
+	ReasonCapacity
+	ReasonReplaced
+)
+
+// cacheMetrics holds the atomic counters backing Cache.Metrics. It must
+// only ever be embedded as a value inside Cache so it is never copied
+// after first use.
+type cacheMetrics struct {
+	insertions    atomic.Uint64
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	evictions     [4]atomic.Uint64
+	fallbackCalls atomic.Uint64
+}
+
+func (m *cacheMetrics) recordEviction(reason EvictionReason) {
+	m.evictions[reason].Add(1)
+}
+
+func (m *cacheMetrics) snapshot() Metrics {
+	byReason := make(map[EvictionReason]uint64, len(m.evictions))
+
+	var total uint64
+
+	for reason := range m.evictions {
+		count := m.evictions[reason].Load()
+		byReason[EvictionReason(reason)] = count
+		total += count
+	}
+
+	return Metrics{
+		Insertions:        m.insertions.Load(),
+		Hits:              m.hits.Load(),
+		Misses:            m.misses.Load(),
+		Evictions:         total,
+		EvictionsByReason: byReason,
+		FallbackCalls:     m.fallbackCalls.Load(),
+	}
+}
+
+// Metrics is a point-in-time snapshot of a Cache's counters.
+type Metrics struct {
+	Insertions        uint64
+	Hits              uint64
+	Misses            uint64
+	Evictions         uint64
+	EvictionsByReason map[EvictionReason]uint64
+	FallbackCalls     uint64
+}
+
+// Metrics returns a snapshot of the cache's counters.
+func (c *Cache[K, V]) Metrics() Metrics {
+	return c.metrics.snapshot()
+}
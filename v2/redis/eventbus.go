@@ -0,0 +1,58 @@
+// Package redis provides a lockotron/v2 EventBus backed by Redis pub/sub,
+// letting multiple processes that share an upstream data source invalidate
+// each other's caches. It has no dependency on the core lockotron/v2
+// package: EventBus is satisfied structurally, so importing this package
+// is opt-in and never pulled in by lockotron/v2 itself.
+package redis
+
+import (
+	"context"
+	"io"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EventBus publishes and subscribes to cache invalidation messages over a
+// single Redis pub/sub channel. A single EventBus is meant to be shared
+// across every Cache (and every ShardedCache shard) backed by the same
+// channel; Subscribe hands each caller back its own subscription instead
+// of one shared by the whole bus, so one Cache closing down never affects
+// another's.
+type EventBus struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewEventBus returns an EventBus that publishes to, and subscribes on,
+// channel using client.
+func NewEventBus(client *redis.Client, channel string) *EventBus {
+	return &EventBus{client: client, channel: channel}
+}
+
+// Publish sends key to every subscriber of the channel, including this
+// process's own.
+func (b *EventBus) Publish(key string) error {
+	return b.client.Publish(context.Background(), b.channel, key).Err()
+}
+
+// Subscribe starts a goroutine that calls fn with the payload of every
+// message received on the channel, including this process's own
+// publishes; callers are expected to filter those out themselves (as
+// lockotron/v2.Cache does via its per-Cache instance ID). The returned
+// io.Closer stops that goroutine and releases its own Redis connection
+// when closed, leaving every other subscriber on the channel untouched.
+func (b *EventBus) Subscribe(fn func(key string)) (io.Closer, error) {
+	sub := b.client.Subscribe(context.Background(), b.channel)
+
+	if _, err := sub.Receive(context.Background()); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for msg := range sub.Channel() {
+			fn(msg.Payload)
+		}
+	}()
+
+	return sub, nil
+}
@@ -0,0 +1,171 @@
+package lockotron
+
+import (
+	"errors"
+	"hash/fnv"
+	"time"
+)
+
+// ShardedCache partitions keys across shardCount independent Cache
+// instances, each with its own mutex, items map, locker and expiration
+// queue, so that concurrent Get/Set/Fetch calls for different keys don't
+// contend on a single lock.
+type ShardedCache[K ~string, V any] struct {
+	shards     []*Cache[K, V]
+	shardCount uint64
+}
+
+// NewShardedCache builds a ShardedCache with shardCount shards, each
+// configured identically from config. shardCount is clamped to 1 if a
+// non-positive value is passed.
+//
+// If config sets an EventBus, every shard's Cache subscribes to the same
+// bus/channel independently (each with its own subscription, so closing
+// one shard never affects another - see EventBus.Subscribe). That means
+// a single Set/Delete on this ShardedCache publishes one invalidation
+// that every other shard in the same process also receives and discards
+// via applyRemoteInvalidation, since the key never lives in the wrong
+// shard's map: correct, but shardCount times more pub/sub traffic than a
+// single unsharded Cache would produce for the same write.
+func NewShardedCache[K ~string, V any](config *Config, shardCount int) *ShardedCache[K, V] {
+	if shardCount <= 0 {
+		shardCount = 1
+	}
+
+	shards := make([]*Cache[K, V], shardCount)
+	for i := range shards {
+		shards[i] = NewCache[K, V](config)
+	}
+
+	return &ShardedCache[K, V]{shards: shards, shardCount: uint64(shardCount)}
+}
+
+// Close closes every shard unconditionally, so one shard's error (e.g. a
+// failed EventBus teardown) can't leave later shards' tickers, schedulers
+// and event dispatchers running. It returns the joined errors, if any.
+func (c *ShardedCache[K, V]) Close() error {
+	var errs []error
+
+	for _, shard := range c.shards {
+		if err := shard.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *ShardedCache[K, V]) Set(key K, value V) {
+	c.shardFor(key).Set(key, value)
+}
+
+func (c *ShardedCache[K, V]) SetEx(key K, ttl time.Duration, value V) {
+	c.shardFor(key).SetEx(key, ttl, value)
+}
+
+func (c *ShardedCache[K, V]) Get(key K) (V, error) {
+	return c.shardFor(key).Get(key)
+}
+
+// GetList groups keys by shard so each shard is locked at most once,
+// then reassembles the hits in the same relative order as keys.
+func (c *ShardedCache[K, V]) GetList(keys []K) []V {
+	buckets := c.bucket(keys)
+
+	resolved := make([]V, len(keys))
+	found := make([]bool, len(keys))
+
+	for shardIdx, bucket := range buckets {
+		values, ok := c.shards[shardIdx].getIndexed(bucket.keys)
+
+		for i, present := range ok {
+			if present {
+				index := bucket.indices[i]
+				resolved[index] = values[i]
+				found[index] = true
+			}
+		}
+	}
+
+	result := make([]V, 0, len(keys))
+
+	for i, present := range found {
+		if present {
+			result = append(result, resolved[i])
+		}
+	}
+
+	return result
+}
+
+func (c *ShardedCache[K, V]) Delete(key K) {
+	c.shardFor(key).Delete(key)
+}
+
+// DeleteList groups keys by shard so each shard is locked at most once
+// for the whole batch instead of once per key.
+func (c *ShardedCache[K, V]) DeleteList(keys []K) {
+	for shardIdx, bucket := range c.bucket(keys) {
+		c.shards[shardIdx].DeleteList(bucket.keys)
+	}
+}
+
+func (c *ShardedCache[K, V]) Fetch(key K, fallback fallbackFunc[K, V]) (V, error) {
+	return c.shardFor(key).Fetch(key, fallback)
+}
+
+func (c *ShardedCache[K, V]) FetchEx(key K, ttl time.Duration, fallback fallbackFunc[K, V]) (V, error) {
+	return c.shardFor(key).FetchEx(key, ttl, fallback)
+}
+
+func (c *ShardedCache[K, V]) DeleteAll() {
+	for _, shard := range c.shards {
+		shard.DeleteAll()
+	}
+}
+
+func (c *ShardedCache[K, V]) DeleteExpired() {
+	for _, shard := range c.shards {
+		shard.DeleteExpired()
+	}
+}
+
+func (c *ShardedCache[K, V]) shardFor(key K) *Cache[K, V] {
+	return c.shards[hashKey(key)%c.shardCount]
+}
+
+type shardBucket[K ~string] struct {
+	keys    []K
+	indices []int
+}
+
+// bucket groups keys by the shard they hash to, recording each key's
+// position in the original slice so callers can reassemble ordered
+// results after dispatching one batch per shard.
+func (c *ShardedCache[K, V]) bucket(keys []K) map[uint64]*shardBucket[K] {
+	buckets := make(map[uint64]*shardBucket[K])
+
+	for i, key := range keys {
+		shardIdx := hashKey(key) % c.shardCount
+
+		b, ok := buckets[shardIdx]
+		if !ok {
+			b = &shardBucket[K]{}
+			buckets[shardIdx] = b
+		}
+
+		b.keys = append(b.keys, key)
+		b.indices = append(b.indices, i)
+	}
+
+	return buckets
+}
+
+// hashKey hashes key with fnv64a, the same fast non-cryptographic hash
+// used to pick shards without becoming a bottleneck itself.
+func hashKey[K ~string](key K) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return h.Sum64()
+}
@@ -0,0 +1,142 @@
+package lockotron
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache_SetGet(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It stores and retrieves values regardless of which shard a key lands on", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		for i := 0; i < 20; i++ {
+			cache.Set(string(rune('a'+i)), string(rune('A'+i)))
+		}
+
+		for i := 0; i < 20; i++ {
+			value, err := cache.Get(string(rune('a' + i)))
+
+			require.Nil(t, err)
+			require.Equal(t, string(rune('A'+i)), value)
+		}
+	})
+
+	t.Run("It falls back to a single shard when shardCount is non-positive", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 0)
+		defer cache.Close()
+
+		cache.Set("key", "value")
+		value, err := cache.Get("key")
+
+		require.Nil(t, err)
+		require.Equal(t, "value", value)
+	})
+}
+
+func TestShardedCache_GetList(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It returns hits in the same order as the requested keys, across shards", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.Set("key3", "value3")
+
+		values := cache.GetList([]string{"key3", "missing", "key1", "key2"})
+
+		require.Equal(t, []string{"value3", "value1", "value2"}, values)
+	})
+}
+
+func TestShardedCache_DeleteList(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It deletes keys spread across shards", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.Set("key3", "value3")
+
+		cache.DeleteList([]string{"key1", "key3"})
+
+		_, err1 := cache.Get("key1")
+		value2, err2 := cache.Get("key2")
+		_, err3 := cache.Get("key3")
+
+		require.Equal(t, ErrNotFound, err1)
+		require.Nil(t, err2)
+		require.Equal(t, "value2", value2)
+		require.Equal(t, ErrNotFound, err3)
+	})
+}
+
+func TestShardedCache_Fetch(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It calls fallback once and shares the result across repeated calls", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		calls := 0
+		fallback := func(string) (string, error) {
+			calls++
+
+			return "value", nil
+		}
+
+		value1, err1 := cache.Fetch("key", fallback)
+		value2, err2 := cache.Fetch("key", fallback)
+
+		require.Nil(t, err1)
+		require.Nil(t, err2)
+		require.Equal(t, "value", value1)
+		require.Equal(t, "value", value2)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("It propagates the fallback error", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		expectedErr := errors.New("terrible error")
+
+		value, err := cache.Fetch("key", func(string) (string, error) {
+			return "", expectedErr
+		})
+
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, "", value)
+	})
+}
+
+func TestShardedCache_DeleteAll(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("It clears every shard", func(t *testing.T) {
+		cache := NewShardedCache[string, string](config, 4)
+		defer cache.Close()
+
+		cache.Set("key1", "value1")
+		cache.Set("key2", "value2")
+		cache.Set("key3", "value3")
+
+		cache.DeleteAll()
+
+		_, err1 := cache.Get("key1")
+		_, err2 := cache.Get("key2")
+		_, err3 := cache.Get("key3")
+
+		require.Equal(t, ErrNotFound, err1)
+		require.Equal(t, ErrNotFound, err2)
+		require.Equal(t, ErrNotFound, err3)
+	})
+}